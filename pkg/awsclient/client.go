@@ -0,0 +1,24 @@
+package awsclient
+
+// Client is the subset of *awsClient's load balancer methods consumed by
+// callers outside this package, most notably the cloudclient/aws backend.
+// It exists as a seam so those callers depend on an interface rather than
+// the concrete, unexported awsClient type.
+type Client interface {
+	CreateClassicELB(elbName string, subnets []string, listenerPort int64, cfg *ELBConfig) (*AWSLoadBalancer, error)
+	SetLoadBalancerPrivate(elbName string) error
+	SetLoadBalancerPublic(elbName string, listenerPort int64) error
+	AddLoadBalancerInstances(elbName string, instanceIds []string) error
+	RemoveInstancesFromLoadBalancer(elbName string, instanceIds []string) error
+	DoesELBExist(elbName string) (bool, *AWSLoadBalancer, error)
+	DeleteClassicELB(elbName string) error
+	UpdateELBHealthCheck(elbName string, hc ELBHealthCheck) error
+	ListAllNLBs() ([]LoadBalancerV2, error)
+	CreateNetworkLoadBalancer(lbName, scheme, subnet, infraID string, attrs *NLBAttributes) ([]LoadBalancerV2, error)
+	CreateExternalNLBTargetGroup(nlbName, vpcID, infraID string) (string, error)
+	DeleteExternalLoadBalancer(extLoadBalancerArn string) error
+	FindLoadBalancersByCluster(infraID string) ([]string, error)
+	EnsureExternalNLB(name, vpcID string, subnets []string, masterIPs []string, infraID string) (*LoadBalancerV2, error)
+}
+
+var _ Client = &awsClient{}
@@ -4,12 +4,46 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elb"
 )
 
+// clusterOwnershipTagKeyFmt and clusterOwnershipTagValue follow the tagging
+// convention used by the upstream Kubernetes AWS cloud provider to mark
+// resources as owned by a given cluster, so that this operator's own
+// ELBs/NLBs/target groups can be told apart from user-created ones.
+const (
+	clusterOwnershipTagKeyFmt = "kubernetes.io/cluster/%s"
+	clusterOwnershipTagValue  = "owned"
+)
+
+// clusterTags returns the ownership tags every load balancer resource this
+// operator creates should carry, keyed by the cluster's infra ID.
+func clusterTags(infraID string) map[string]string {
+	return map[string]string{
+		fmt.Sprintf(clusterOwnershipTagKeyFmt, infraID): clusterOwnershipTagValue,
+	}
+}
+
+func classicELBTags(infraID string) []*elb.Tag {
+	tags := make([]*elb.Tag, 0, 1)
+	for k, v := range clusterTags(infraID) {
+		tags = append(tags, &elb.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	return tags
+}
+
+func loadBalancerV2Tags(infraID string) []*elbv2.Tag {
+	tags := make([]*elbv2.Tag, 0, 1)
+	for k, v := range clusterTags(infraID) {
+		tags = append(tags, &elbv2.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	return tags
+}
+
 // AWSLoadBalancer a handy way to return information about an ELB
 type AWSLoadBalancer struct {
 	ELBName   string // Name of the ELB
@@ -17,12 +51,100 @@ type AWSLoadBalancer struct {
 	DNSZoneId string // Zone ID
 }
 
+// proxyProtocolPolicyName is the name given to the PROXY protocol policy
+// created on the classic ELB. This mirrors the policy name used by the
+// upstream Kubernetes AWS cloud provider.
+const proxyProtocolPolicyName = "k8s-proxyprotocol-enabled"
+
+// ELBConfig carries optional, non-default behavior for CreateClassicELB so
+// that callers who don't need it can keep passing the bare listener info.
+type ELBConfig struct {
+	// ProxyProtocol, when true, enables the PROXY protocol v2 policy on the
+	// ELB's backend instance ports so the real client source IP is preserved
+	// all the way to the kube-apiserver.
+	ProxyProtocol bool
+	// InstancePorts lists the backend instance ports the PROXY protocol
+	// policy should be applied to. Defaults to 6443 (kube-apiserver) if empty.
+	InstancePorts []int64
+	// HealthCheck overrides the default HTTPS:6443/readyz health check.
+	// Nil means use defaultELBHealthCheck(); a non-nil pointer is used
+	// as-is, including any fields explicitly left at their zero value.
+	HealthCheck *ELBHealthCheck
+	// InfraID is the cluster's infra ID. When set, the ELB is tagged with
+	// the kubernetes.io/cluster/<InfraID>=owned ownership tag.
+	InfraID string
+	// Attributes overrides the default cross-zone/idle-timeout attributes.
+	// Nil means use defaultELBAttributes(); a non-nil pointer is used
+	// as-is, including any fields explicitly left at their zero value.
+	Attributes *ELBAttributes
+}
+
+// ELBAttributes configures a classic ELB's load balancer attributes.
+type ELBAttributes struct {
+	CrossZoneLoadBalancing    bool
+	ConnectionDraining        bool
+	ConnectionDrainingTimeout int64
+	IdleTimeout               int64
+}
+
+// defaultELBAttributes enables cross-zone load balancing and sets a long
+// idle timeout so that long-lived connections to the kube-apiserver (eg a
+// streamed `kubectl exec`) survive.
+func defaultELBAttributes() ELBAttributes {
+	return ELBAttributes{
+		CrossZoneLoadBalancing:    true,
+		ConnectionDraining:        true,
+		ConnectionDrainingTimeout: 300,
+		IdleTimeout:               3600,
+	}
+}
+
+// NLBAttributes configures an NLB's load balancer attributes.
+type NLBAttributes struct {
+	CrossZoneLoadBalancing bool
+}
+
+// defaultNLBAttributes mirrors defaultELBAttributes' cross-zone default;
+// NLBs have no idle timeout or connection draining attribute to set.
+func defaultNLBAttributes() NLBAttributes {
+	return NLBAttributes{CrossZoneLoadBalancing: true}
+}
+
+// ELBHealthCheck configures the classic ELB's backend health check.
+type ELBHealthCheck struct {
+	// Protocol is the health check protocol, eg "HTTPS" or "SSL".
+	Protocol string
+	// Path is the health check path. Ignored for TCP-only protocols (SSL).
+	Path               string
+	Port               int64
+	HealthyThreshold   int64
+	UnhealthyThreshold int64
+	Interval           int64
+	Timeout            int64
+}
+
+// defaultELBHealthCheck returns the classic ELB health check defaults,
+// matching the upstream AWS cloud provider and the NLB target group: an
+// HTTPS check against kube-apiserver's /readyz.
+func defaultELBHealthCheck() ELBHealthCheck {
+	return ELBHealthCheck{
+		Protocol:           "HTTPS",
+		Path:               "/readyz",
+		Port:               6443,
+		HealthyThreshold:   2,
+		UnhealthyThreshold: 2,
+		Interval:           10,
+		Timeout:            10,
+	}
+}
+
 // CreateClassicELB creates a classic ELB in Amazon, as in for management API endpoint.
 // inputs are the name of the ELB, the availability zone(s) and subnet(s) the
 // ELB should attend, as well as the listener port.
 // The port is used for the instance port and load balancer port
+// cfg may be nil, in which case the ELB is created with no optional behavior.
 // Return is the (FQDN) DNS name from Amazon, and error, if any.
-func (c *awsClient) CreateClassicELB(elbName string, subnets []string, listenerPort int64) (*AWSLoadBalancer, error) {
+func (c *awsClient) CreateClassicELB(elbName string, subnets []string, listenerPort int64, cfg *ELBConfig) (*AWSLoadBalancer, error) {
 	fmt.Printf("  * CreateClassicELB(%s,%s,%d)\n", elbName, subnets, listenerPort)
 	i := &elb.CreateLoadBalancerInput{
 		LoadBalancerName: aws.String(elbName),
@@ -37,13 +159,36 @@ func (c *awsClient) CreateClassicELB(elbName string, subnets []string, listenerP
 			},
 		},
 	}
+	if cfg != nil && cfg.InfraID != "" {
+		i.Tags = classicELBTags(cfg.InfraID)
+	}
 	_, err := c.CreateLoadBalancer(i)
 	if err != nil {
 		return &AWSLoadBalancer{}, err
 	}
-	fmt.Printf("    * Adding health check (HTTP:6443/)\n")
-	err = c.addHealthCheck(elbName, "HTTP", "/", 6443)
-	if err != nil {
+	hc := defaultELBHealthCheck()
+	if cfg != nil && cfg.HealthCheck != nil {
+		hc = *cfg.HealthCheck
+	}
+	fmt.Printf("    * Adding health check (%s:%d%s)\n", hc.Protocol, hc.Port, hc.Path)
+	if err := c.addHealthCheck(elbName, hc); err != nil {
+		return &AWSLoadBalancer{}, err
+	}
+	if cfg != nil && cfg.ProxyProtocol {
+		instancePorts := cfg.InstancePorts
+		if len(instancePorts) == 0 {
+			instancePorts = []int64{6443}
+		}
+		fmt.Printf("    * Enabling PROXY protocol on %s\n", elbName)
+		if err := c.EnableProxyProtocol(elbName, instancePorts); err != nil {
+			return &AWSLoadBalancer{}, err
+		}
+	}
+	attrs := defaultELBAttributes()
+	if cfg != nil && cfg.Attributes != nil {
+		attrs = *cfg.Attributes
+	}
+	if err := c.SetClassicELBAttributes(elbName, attrs); err != nil {
 		return &AWSLoadBalancer{}, err
 	}
 	// Caller will need the DNS name and Zone ID for the ELB (for route53) so let's make a handy object to return, using the
@@ -54,6 +199,62 @@ func (c *awsClient) CreateClassicELB(elbName string, subnets []string, listenerP
 	return awsELBObj, nil
 }
 
+// EnableProxyProtocol enables the PROXY protocol v2 policy on elbName and
+// applies it to each of instancePorts, preserving the client source IP all
+// the way to the backend instances (eg the kube-apiserver on 6443). This
+// mirrors the k8s-proxyprotocol-enabled policy the upstream Kubernetes AWS
+// cloud provider creates on classic ELBs.
+func (c *awsClient) EnableProxyProtocol(elbName string, instancePorts []int64) error {
+	cp := &elb.CreateLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String(elbName),
+		PolicyName:       aws.String(proxyProtocolPolicyName),
+		PolicyTypeName:   aws.String("ProxyProtocolPolicyType"),
+		PolicyAttributes: []*elb.PolicyAttribute{
+			{
+				AttributeName:  aws.String("ProxyProtocol"),
+				AttributeValue: aws.String("true"),
+			},
+		},
+	}
+	if _, err := c.CreateLoadBalancerPolicy(cp); err != nil {
+		return err
+	}
+	for _, port := range instancePorts {
+		sp := &elb.SetLoadBalancerPoliciesForBackendServerInput{
+			LoadBalancerName: aws.String(elbName),
+			InstancePort:     aws.Int64(port),
+			PolicyNames:      []*string{aws.String(proxyProtocolPolicyName)},
+		}
+		if _, err := c.SetLoadBalancerPoliciesForBackendServer(sp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisableProxyProtocol removes the PROXY protocol policy from each of
+// elbName's instancePorts and deletes the policy itself. instancePorts must
+// match whatever was passed to EnableProxyProtocol, since the policy can
+// only be deleted once it is no longer assigned to any backend port.
+func (c *awsClient) DisableProxyProtocol(elbName string, instancePorts []int64) error {
+	for _, port := range instancePorts {
+		sp := &elb.SetLoadBalancerPoliciesForBackendServerInput{
+			LoadBalancerName: aws.String(elbName),
+			InstancePort:     aws.Int64(port),
+			PolicyNames:      []*string{},
+		}
+		if _, err := c.SetLoadBalancerPoliciesForBackendServer(sp); err != nil {
+			return err
+		}
+	}
+	dp := &elb.DeleteLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String(elbName),
+		PolicyName:       aws.String(proxyProtocolPolicyName),
+	}
+	_, err := c.DeleteLoadBalancerPolicy(dp)
+	return err
+}
+
 // SetLoadBalancerPrivate sets a load balancer private by removing its
 // listeners (port 6443/TCP)
 func (c *awsClient) SetLoadBalancerPrivate(elbName string) error {
@@ -156,6 +357,15 @@ func (c *awsClient) DoesELBExist(elbName string) (bool, *AWSLoadBalancer, error)
 	return true, &AWSLoadBalancer{ELBName: elbName, DNSName: *res.LoadBalancerDescriptions[0].DNSName, DNSZoneId: *res.LoadBalancerDescriptions[0].CanonicalHostedZoneNameID}, nil
 }
 
+// DeleteClassicELB deletes the classic ELB named elbName.
+func (c *awsClient) DeleteClassicELB(elbName string) error {
+	i := &elb.DeleteLoadBalancerInput{
+		LoadBalancerName: aws.String(elbName),
+	}
+	_, err := c.DeleteLoadBalancer(i)
+	return err
+}
+
 // LoadBalancerV2 is a list of all non-classic ELBs
 type LoadBalancerV2 struct {
 	CanonicalHostedZoneNameID string
@@ -197,8 +407,11 @@ func (c *awsClient) DeleteExternalLoadBalancer(extLoadBalancerArn string) error
 	return err
 }
 
-// CreateNetworkLoadBalancer should only return one new NLB at a time
-func (c *awsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet string) ([]LoadBalancerV2, error) {
+// CreateNetworkLoadBalancer should only return one new NLB at a time.
+// infraID, when non-empty, tags the NLB with the cluster ownership tag.
+// attrs overrides the default cross-zone load balancing attribute; pass nil
+// to use defaultNLBAttributes().
+func (c *awsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet, infraID string, attrs *NLBAttributes) ([]LoadBalancerV2, error) {
 	i := &elbv2.CreateLoadBalancerInput{
 		Name:   aws.String(lbName),
 		Scheme: aws.String(scheme),
@@ -207,6 +420,9 @@ func (c *awsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet string) ([]
 		},
 		Type: aws.String("network"),
 	}
+	if infraID != "" {
+		i.Tags = loadBalancerV2Tags(infraID)
+	}
 
 	result, err := c.CreateLoadBalancerV2(i)
 	if err != nil {
@@ -226,11 +442,22 @@ func (c *awsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet string) ([]
 			VpcID:                     aws.StringValue(loadBalancer.VpcId),
 		})
 	}
+	nlbAttrs := defaultNLBAttributes()
+	if attrs != nil {
+		nlbAttrs = *attrs
+	}
+	for _, lb := range loadBalancers {
+		if err := c.SetNLBAttributes(lb.LoadBalancerArn, nlbAttrs); err != nil {
+			return loadBalancers, err
+		}
+	}
 	return loadBalancers, nil
 }
 
-// create the external NLB target group and returns the targetGroupArn
-func (c *awsClient) CreateExternalNLBTargetGroup(nlbName, vpcID string) (string, error) {
+// create the external NLB target group and returns the targetGroupArn.
+// infraID, when non-empty, tags the target group with the cluster
+// ownership tag.
+func (c *awsClient) CreateExternalNLBTargetGroup(nlbName, vpcID, infraID string) (string, error) {
 	i := &elbv2.CreateTargetGroupInput{
 		Name:                       aws.String(nlbName),
 		Port:                       aws.Int64(6443),
@@ -245,6 +472,9 @@ func (c *awsClient) CreateExternalNLBTargetGroup(nlbName, vpcID string) (string,
 		HealthyThresholdCount:      aws.Int64(2),
 		UnhealthyThresholdCount:    aws.Int64(2),
 	}
+	if infraID != "" {
+		i.Tags = loadBalancerV2Tags(infraID)
+	}
 
 	result, err := c.CreateTargetGroupV2(i)
 	if err != nil {
@@ -254,34 +484,301 @@ func (c *awsClient) CreateExternalNLBTargetGroup(nlbName, vpcID string) (string,
 	return aws.StringValue(result.TargetGroups[0].TargetGroupArn), nil
 }
 
-// type TargetDescription struct {
-// 	AvailabilityZone string
-// 	Id string
-// 	Port string
-// }
+// RegisterMasterNodeIPs registers ips as targets of targetGroupArn on port.
+// AvailabilityZone is set to "all" so that targets addressed by IP (rather
+// than instance ID) are reachable regardless of which AZ they land in, as
+// required by the NLB target group.
+func (c *awsClient) RegisterMasterNodeIPs(targetGroupArn string, ips []string, port int64) error {
+	targets := make([]*elbv2.TargetDescription, 0, len(ips))
+	for _, ip := range ips {
+		targets = append(targets, &elbv2.TargetDescription{
+			Id:               aws.String(ip),
+			Port:             aws.Int64(port),
+			AvailabilityZone: aws.String("all"),
+		})
+	}
+	i := &elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+		Targets:        targets,
+	}
+	_, err := c.RegisterTargetsV2(i)
+	return err
+}
+
+// DeregisterMasterNodeIPs removes ips from targetGroupArn, eg when a master
+// node is replaced or scaled down.
+func (c *awsClient) DeregisterMasterNodeIPs(targetGroupArn string, ips []string, port int64) error {
+	targets := make([]*elbv2.TargetDescription, 0, len(ips))
+	for _, ip := range ips {
+		targets = append(targets, &elbv2.TargetDescription{
+			Id:               aws.String(ip),
+			Port:             aws.Int64(port),
+			AvailabilityZone: aws.String("all"),
+		})
+	}
+	i := &elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+		Targets:        targets,
+	}
+	_, err := c.DeregisterTargetsV2(i)
+	return err
+}
+
+// DescribeTargetHealth returns the health of every target currently
+// registered with targetGroupArn.
+func (c *awsClient) DescribeTargetHealth(targetGroupArn string) ([]*elbv2.TargetHealthDescription, error) {
+	i := &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+	}
+	output, err := c.DescribeTargetHealthV2(i)
+	if err != nil {
+		return nil, err
+	}
+	return output.TargetHealthDescriptions, nil
+}
+
+// CreateNLBListener creates a TCP listener on lbArn for port that forwards
+// all traffic to tgArn, eg 6443 for the kube-apiserver.
+func (c *awsClient) CreateNLBListener(lbArn, tgArn string, port int64) error {
+	i := &elbv2.CreateListenerInput{
+		LoadBalancerArn: aws.String(lbArn),
+		Protocol:        aws.String("TCP"),
+		Port:            aws.Int64(port),
+		DefaultActions: []*elbv2.Action{
+			{
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+				TargetGroupArn: aws.String(tgArn),
+			},
+		},
+	}
+	_, err := c.CreateListenerV2(i)
+	return err
+}
+
+// DoesNLBExist checks for the existence of an NLB by name. If there's an AWS
+// error it is returned.
+func (c *awsClient) DoesNLBExist(name string) (bool, *LoadBalancerV2, error) {
+	i := &elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(name)},
+	}
+	output, err := c.DescribeLoadBalancersV2(i)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case elbv2.ErrCodeLoadBalancerNotFoundException:
+				return false, &LoadBalancerV2{}, nil
+			default:
+				return false, &LoadBalancerV2{}, err
+			}
+		}
+		return false, &LoadBalancerV2{}, err
+	}
+	lb := output.LoadBalancers[0]
+	return true, &LoadBalancerV2{
+		CanonicalHostedZoneNameID: aws.StringValue(lb.CanonicalHostedZoneId),
+		DNSName:                   aws.StringValue(lb.DNSName),
+		LoadBalancerArn:           aws.StringValue(lb.LoadBalancerArn),
+		LoadBalancerName:          aws.StringValue(lb.LoadBalancerName),
+		Scheme:                    aws.StringValue(lb.Scheme),
+		VpcID:                     aws.StringValue(lb.VpcId),
+	}, nil
+}
+
+// EnsureExternalNLB idempotently creates (or reuses) the external NLB named
+// name along with its target group and 6443 listener, registers masterIPs
+// against the target group, and returns the resulting load balancer. This
+// mirrors the kops network_load_balancer.go task flow and is the NLB
+// equivalent of CreateClassicELB, letting the operator transition a
+// cluster's kube-api endpoint from a classic ELB to an NLB. infraID, when
+// non-empty, tags the NLB and target group it creates with the cluster
+// ownership tag; the listener is not tagged.
+func (c *awsClient) EnsureExternalNLB(name, vpcID string, subnets []string, masterIPs []string, infraID string) (*LoadBalancerV2, error) {
+	exists, lb, err := c.DoesNLBExist(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		fmt.Printf("  * EnsureExternalNLB(%s,%s,%s): creating NLB\n", name, vpcID, subnets)
+		scheme := elbv2.LoadBalancerSchemeEnumInternetFacing
+		if len(subnets) == 0 {
+			return nil, fmt.Errorf("at least one subnet is required to create NLB %s", name)
+		}
+		lbs, err := c.CreateNetworkLoadBalancer(name, scheme, subnets[0], infraID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(lbs) != 1 {
+			return nil, fmt.Errorf("expected exactly one NLB to be created for %s, got %d", name, len(lbs))
+		}
+		lb = &lbs[0]
+	}
+
+	tgArn, tgExists, err := c.findExternalNLBTargetGroupArn(name)
+	if err != nil {
+		return nil, err
+	}
+	if !tgExists {
+		tgArn, err = c.CreateExternalNLBTargetGroup(name, vpcID, infraID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	listenerExists, err := c.doesNLBListenerExist(lb.LoadBalancerArn, 6443)
+	if err != nil {
+		return nil, err
+	}
+	if !listenerExists {
+		if err := c.CreateNLBListener(lb.LoadBalancerArn, tgArn, 6443); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.RegisterMasterNodeIPs(tgArn, masterIPs, 6443); err != nil {
+		return nil, err
+	}
+
+	return lb, nil
+}
+
+// findExternalNLBTargetGroupArn looks up the target group named nlbName and
+// returns its ARN, or ("", false, nil) if it does not exist yet.
+func (c *awsClient) findExternalNLBTargetGroupArn(nlbName string) (string, bool, error) {
+	i := &elbv2.DescribeTargetGroupsInput{
+		Names: []*string{aws.String(nlbName)},
+	}
+	output, err := c.DescribeTargetGroupsV2(i)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case elbv2.ErrCodeTargetGroupNotFoundException:
+				return "", false, nil
+			default:
+				return "", false, err
+			}
+		}
+		return "", false, err
+	}
+	if len(output.TargetGroups) == 0 {
+		return "", false, nil
+	}
+	return aws.StringValue(output.TargetGroups[0].TargetGroupArn), true, nil
+}
 
-// func (c *awsClient) RegisterMasterNodeIPs(targetGroupArn string, ) error {
-// 	i := &elbv2.RegisterTargetsInput{
-// 		TargetGroupArn: aws.String(targetGroupArn),
-// 		Targets: []*elbv2.TargetDescription{
-// 			{
+// doesNLBListenerExist checks whether lbArn already has a listener on port.
+func (c *awsClient) doesNLBListenerExist(lbArn string, port int64) (bool, error) {
+	i := &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(lbArn),
+	}
+	output, err := c.DescribeListenersV2(i)
+	if err != nil {
+		return false, err
+	}
+	for _, listener := range output.Listeners {
+		if aws.Int64Value(listener.Port) == port {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
-// 			}
-// 		}
-// 	}
-// }
+// healthCheckTarget builds the "PROTOCOL:PORT/PATH" target string
+// ConfigureHealthCheckInput expects. TCP-only protocols (SSL, TCP) have no
+// path component.
+func healthCheckTarget(hc ELBHealthCheck) string {
+	switch hc.Protocol {
+	case "SSL", "TCP":
+		return fmt.Sprintf("%s:%d", hc.Protocol, hc.Port)
+	default:
+		return fmt.Sprintf("%s:%d%s", hc.Protocol, hc.Port, hc.Path)
+	}
+}
 
-func (c *awsClient) addHealthCheck(loadBalancerName, protocol, path string, port int64) error {
+func (c *awsClient) addHealthCheck(loadBalancerName string, hc ELBHealthCheck) error {
 	i := &elb.ConfigureHealthCheckInput{
 		HealthCheck: &elb.HealthCheck{
-			HealthyThreshold:   aws.Int64(2),
-			Interval:           aws.Int64(30),
-			Target:             aws.String(fmt.Sprintf("%s:%d%s", protocol, port, path)),
-			Timeout:            aws.Int64(3),
-			UnhealthyThreshold: aws.Int64(2),
+			HealthyThreshold:   aws.Int64(hc.HealthyThreshold),
+			Interval:           aws.Int64(hc.Interval),
+			Target:             aws.String(healthCheckTarget(hc)),
+			Timeout:            aws.Int64(hc.Timeout),
+			UnhealthyThreshold: aws.Int64(hc.UnhealthyThreshold),
 		},
 		LoadBalancerName: aws.String(loadBalancerName),
 	}
 	_, err := c.ConfigureHealthCheck(i)
 	return err
 }
+
+// UpdateELBHealthCheck reconciles elbName's health check to match hc, eg
+// when the operator detects configuration drift.
+func (c *awsClient) UpdateELBHealthCheck(elbName string, hc ELBHealthCheck) error {
+	return c.addHealthCheck(elbName, hc)
+}
+
+// FindLoadBalancersByCluster lists the ARNs of every classic ELB, NLB, and
+// target group tagged as owned by infraID, via the Resource Groups Tagging
+// API (the only AWS API that can query load balancer resources by tag
+// across both the classic and v2 ELB resource types). This lets the
+// operator safely distinguish its own load balancers from user-created
+// ones when reconciling or pruning.
+func (c *awsClient) FindLoadBalancersByCluster(infraID string) ([]string, error) {
+	i := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice([]string{
+			"elasticloadbalancing:loadbalancer",
+			"elasticloadbalancing:targetgroup",
+		}),
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{
+			{
+				Key:    aws.String(fmt.Sprintf(clusterOwnershipTagKeyFmt, infraID)),
+				Values: aws.StringSlice([]string{clusterOwnershipTagValue}),
+			},
+		},
+	}
+	output, err := c.GetResources(i)
+	if err != nil {
+		return nil, err
+	}
+	arns := make([]string, 0, len(output.ResourceTagMappingList))
+	for _, resource := range output.ResourceTagMappingList {
+		arns = append(arns, aws.StringValue(resource.ResourceARN))
+	}
+	return arns, nil
+}
+
+// SetClassicELBAttributes applies attrs to elbName via
+// ModifyLoadBalancerAttributes.
+func (c *awsClient) SetClassicELBAttributes(elbName string, attrs ELBAttributes) error {
+	i := &elb.ModifyLoadBalancerAttributesInput{
+		LoadBalancerName: aws.String(elbName),
+		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
+				Enabled: aws.Bool(attrs.CrossZoneLoadBalancing),
+			},
+			ConnectionDraining: &elb.ConnectionDraining{
+				Enabled: aws.Bool(attrs.ConnectionDraining),
+				Timeout: aws.Int64(attrs.ConnectionDrainingTimeout),
+			},
+			ConnectionSettings: &elb.ConnectionSettings{
+				IdleTimeout: aws.Int64(attrs.IdleTimeout),
+			},
+		},
+	}
+	_, err := c.ModifyLoadBalancerAttributes(i)
+	return err
+}
+
+// SetNLBAttributes applies attrs to the NLB identified by lbArn via
+// elbv2.ModifyLoadBalancerAttributes.
+func (c *awsClient) SetNLBAttributes(lbArn string, attrs NLBAttributes) error {
+	i := &elbv2.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(lbArn),
+		Attributes: []*elbv2.LoadBalancerAttribute{
+			{
+				Key:   aws.String("load_balancing.cross_zone.enabled"),
+				Value: aws.String(fmt.Sprintf("%t", attrs.CrossZoneLoadBalancing)),
+			},
+		},
+	}
+	_, err := c.ModifyLoadBalancerAttributesV2(i)
+	return err
+}
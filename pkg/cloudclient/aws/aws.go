@@ -0,0 +1,207 @@
+// Package aws is the AWS backend for the cloudclient.LoadBalancer
+// interface. It wraps an awsclient.Client and translates the
+// cloud-agnostic cloudclient.APIEndpointSpec into classic ELB or NLB calls,
+// depending on spec.Type.
+package aws
+
+import (
+	"fmt"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+)
+
+// loadBalancer implements cloudclient.LoadBalancer on top of an
+// awsclient.Client, preserving today's classic-ELB-based behavior.
+type loadBalancer struct {
+	client  awsclient.Client
+	infraID string
+}
+
+// New returns a cloudclient.LoadBalancer backed by client. infraID is the
+// cluster's infra ID and is used to tag every ELB this backend creates.
+func New(client awsclient.Client, infraID string) cloudclient.LoadBalancer {
+	return &loadBalancer{client: client, infraID: infraID}
+}
+
+// elbConfig translates the parts of spec that CreateClassicELB needs into
+// an *awsclient.ELBConfig, carrying the health check override (if any) and
+// this backend's infra ID tag.
+func (l *loadBalancer) elbConfig(spec cloudclient.APIEndpointSpec) *awsclient.ELBConfig {
+	cfg := &awsclient.ELBConfig{InfraID: l.infraID}
+	if spec.HealthCheck != (cloudclient.HealthCheck{}) {
+		cfg.HealthCheck = &awsclient.ELBHealthCheck{
+			Protocol:           spec.HealthCheck.Protocol,
+			Path:               spec.HealthCheck.Path,
+			Port:               spec.HealthCheck.Port,
+			HealthyThreshold:   spec.HealthCheck.HealthyThreshold,
+			UnhealthyThreshold: spec.HealthCheck.UnhealthyThreshold,
+			Interval:           spec.HealthCheck.IntervalSeconds,
+			Timeout:            spec.HealthCheck.TimeoutSeconds,
+		}
+	}
+	return cfg
+}
+
+func (l *loadBalancer) EnsureLoadBalancer(spec cloudclient.APIEndpointSpec) (*cloudclient.LoadBalancerInfo, error) {
+	if spec.Type == cloudclient.LoadBalancerTypeNetwork {
+		return l.ensureNLB(spec)
+	}
+	return l.ensureClassicELB(spec)
+}
+
+func (l *loadBalancer) ensureClassicELB(spec cloudclient.APIEndpointSpec) (*cloudclient.LoadBalancerInfo, error) {
+	if exists, elbInfo, err := l.client.DoesELBExist(spec.Name); err != nil {
+		return nil, err
+	} else if exists {
+		return classicELBInfo(elbInfo), nil
+	}
+
+	listenerPort := int64(6443)
+	if len(spec.ListenerPorts) > 0 {
+		listenerPort = spec.ListenerPorts[0]
+	}
+
+	elb, err := l.client.CreateClassicELB(spec.Name, spec.Subnets, listenerPort, l.elbConfig(spec))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(spec.TargetInstanceIDs) > 0 {
+		if err := l.client.AddLoadBalancerInstances(spec.Name, spec.TargetInstanceIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.Scheme == cloudclient.EndpointSchemeInternal {
+		if err := l.client.SetLoadBalancerPrivate(spec.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return classicELBInfo(elb), nil
+}
+
+// ensureNLB idempotently provisions the NLB described by spec via
+// EnsureExternalNLB, which itself creates (or reuses) the NLB, target
+// group, and listener, and registers spec.TargetIPs. spec.Scheme,
+// spec.ListenerPorts and spec.HealthCheck are not consulted: the NLB is
+// always internet-facing, listens on TCP 6443, and uses the target group's
+// fixed HTTPS:6443/readyz health check.
+func (l *loadBalancer) ensureNLB(spec cloudclient.APIEndpointSpec) (*cloudclient.LoadBalancerInfo, error) {
+	lb, err := l.client.EnsureExternalNLB(spec.Name, spec.VPCID, spec.Subnets, spec.TargetIPs, l.infraID)
+	if err != nil {
+		return nil, err
+	}
+	return nlbInfo(lb), nil
+}
+
+func (l *loadBalancer) UpdateLoadBalancer(spec cloudclient.APIEndpointSpec) error {
+	if spec.Type == cloudclient.LoadBalancerTypeNetwork {
+		// EnsureExternalNLB is idempotent and additive, so re-running it is
+		// also how the NLB path reconciles targets: it registers
+		// spec.TargetIPs, but does not deregister targets missing from spec
+		// and does not reapply attributes to an NLB that already exists.
+		_, err := l.client.EnsureExternalNLB(spec.Name, spec.VPCID, spec.Subnets, spec.TargetIPs, l.infraID)
+		return err
+	}
+
+	exists, _, err := l.client.DoesELBExist(spec.Name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("load balancer %s does not exist", spec.Name)
+	}
+
+	if len(spec.TargetInstanceIDs) > 0 {
+		if err := l.client.AddLoadBalancerInstances(spec.Name, spec.TargetInstanceIDs); err != nil {
+			return err
+		}
+	}
+
+	if spec.HealthCheck != (cloudclient.HealthCheck{}) {
+		if err := l.client.UpdateELBHealthCheck(spec.Name, *l.elbConfig(spec).HealthCheck); err != nil {
+			return err
+		}
+	}
+
+	switch spec.Scheme {
+	case cloudclient.EndpointSchemeInternal:
+		return l.client.SetLoadBalancerPrivate(spec.Name)
+	case cloudclient.EndpointSchemeInternetFacing:
+		listenerPort := int64(6443)
+		if len(spec.ListenerPorts) > 0 {
+			listenerPort = spec.ListenerPorts[0]
+		}
+		return l.client.SetLoadBalancerPublic(spec.Name, listenerPort)
+	}
+	return nil
+}
+
+// GetLoadBalancer looks for name among classic ELBs first, then NLBs, since
+// the name alone doesn't say which kind of load balancer backs it.
+func (l *loadBalancer) GetLoadBalancer(name string) (*cloudclient.LoadBalancerInfo, bool, error) {
+	if exists, elb, err := l.client.DoesELBExist(name); err != nil {
+		return nil, false, err
+	} else if exists {
+		return classicELBInfo(elb), true, nil
+	}
+
+	lb, exists, err := l.findNLB(name)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+	return nlbInfo(lb), true, nil
+}
+
+func (l *loadBalancer) EnsureLoadBalancerDeleted(name string) error {
+	exists, _, err := l.client.DoesELBExist(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return l.client.DeleteClassicELB(name)
+	}
+
+	lb, exists, err := l.findNLB(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	return l.client.DeleteExternalLoadBalancer(lb.LoadBalancerArn)
+}
+
+// findNLB looks up an NLB by name among every NLB in the account, since
+// the awsclient.Client interface has no by-name NLB lookup that also
+// returns the ARN deletion needs.
+func (l *loadBalancer) findNLB(name string) (*awsclient.LoadBalancerV2, bool, error) {
+	nlbs, err := l.client.ListAllNLBs()
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range nlbs {
+		if nlbs[i].LoadBalancerName == name {
+			return &nlbs[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func classicELBInfo(elb *awsclient.AWSLoadBalancer) *cloudclient.LoadBalancerInfo {
+	return &cloudclient.LoadBalancerInfo{
+		Name:              elb.ELBName,
+		DNSName:           elb.DNSName,
+		CanonicalHostedID: elb.DNSZoneId,
+	}
+}
+
+func nlbInfo(lb *awsclient.LoadBalancerV2) *cloudclient.LoadBalancerInfo {
+	return &cloudclient.LoadBalancerInfo{
+		Name:              lb.LoadBalancerName,
+		DNSName:           lb.DNSName,
+		CanonicalHostedID: lb.CanonicalHostedZoneNameID,
+	}
+}
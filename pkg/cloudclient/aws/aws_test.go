@@ -0,0 +1,237 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+)
+
+// mockClient is a minimal, hand-rolled awsclient.Client used to drive the
+// loadBalancer backend's branching without talking to real AWS APIs.
+type mockClient struct {
+	elbExists bool
+	elb       *awsclient.AWSLoadBalancer
+	nlbs      []awsclient.LoadBalancerV2
+
+	createErr               error
+	ensureExternalNLBErr    error
+	deleteClassicELBErr     error
+	deleteExternalErr       error
+	deletedClassicELBName   string
+	deletedExternalLBArn    string
+	ensureExternalNLBCalled bool
+	createClassicELBCalled  bool
+	addedInstanceIDs        []string
+	setPrivateCalled        bool
+	setPublicCalled         bool
+}
+
+func (m *mockClient) CreateClassicELB(elbName string, subnets []string, listenerPort int64, cfg *awsclient.ELBConfig) (*awsclient.AWSLoadBalancer, error) {
+	m.createClassicELBCalled = true
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	return &awsclient.AWSLoadBalancer{ELBName: elbName, DNSName: elbName + ".example.com"}, nil
+}
+
+func (m *mockClient) SetLoadBalancerPrivate(elbName string) error {
+	m.setPrivateCalled = true
+	return nil
+}
+
+func (m *mockClient) SetLoadBalancerPublic(elbName string, listenerPort int64) error {
+	m.setPublicCalled = true
+	return nil
+}
+
+func (m *mockClient) AddLoadBalancerInstances(elbName string, instanceIds []string) error {
+	m.addedInstanceIDs = instanceIds
+	return nil
+}
+
+func (m *mockClient) RemoveInstancesFromLoadBalancer(elbName string, instanceIds []string) error {
+	return nil
+}
+
+func (m *mockClient) DoesELBExist(elbName string) (bool, *awsclient.AWSLoadBalancer, error) {
+	return m.elbExists, m.elb, nil
+}
+
+func (m *mockClient) DeleteClassicELB(elbName string) error {
+	m.deletedClassicELBName = elbName
+	return m.deleteClassicELBErr
+}
+
+func (m *mockClient) UpdateELBHealthCheck(elbName string, hc awsclient.ELBHealthCheck) error {
+	return nil
+}
+
+func (m *mockClient) ListAllNLBs() ([]awsclient.LoadBalancerV2, error) {
+	return m.nlbs, nil
+}
+
+func (m *mockClient) CreateNetworkLoadBalancer(lbName, scheme, subnet, infraID string, attrs *awsclient.NLBAttributes) ([]awsclient.LoadBalancerV2, error) {
+	return nil, nil
+}
+
+func (m *mockClient) CreateExternalNLBTargetGroup(nlbName, vpcID, infraID string) (string, error) {
+	return "", nil
+}
+
+func (m *mockClient) DeleteExternalLoadBalancer(extLoadBalancerArn string) error {
+	m.deletedExternalLBArn = extLoadBalancerArn
+	return m.deleteExternalErr
+}
+
+func (m *mockClient) FindLoadBalancersByCluster(infraID string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockClient) EnsureExternalNLB(name, vpcID string, subnets []string, masterIPs []string, infraID string) (*awsclient.LoadBalancerV2, error) {
+	m.ensureExternalNLBCalled = true
+	if m.ensureExternalNLBErr != nil {
+		return nil, m.ensureExternalNLBErr
+	}
+	return &awsclient.LoadBalancerV2{LoadBalancerName: name, DNSName: name + ".example.com"}, nil
+}
+
+var _ awsclient.Client = &mockClient{}
+
+func TestEnsureLoadBalancerClassic(t *testing.T) {
+	m := &mockClient{}
+	lb := New(m, "infra-1")
+
+	info, err := lb.EnsureLoadBalancer(cloudclient.APIEndpointSpec{Name: "api", TargetInstanceIDs: []string{"i-1"}})
+	if err != nil {
+		t.Fatalf("EnsureLoadBalancer() returned error: %v", err)
+	}
+	if !m.createClassicELBCalled {
+		t.Error("CreateClassicELB was not called for a classic-type spec")
+	}
+	if m.ensureExternalNLBCalled {
+		t.Error("EnsureExternalNLB was called for a classic-type spec")
+	}
+	if info.Name != "api" {
+		t.Errorf("Name = %q, want %q", info.Name, "api")
+	}
+	if len(m.addedInstanceIDs) != 1 || m.addedInstanceIDs[0] != "i-1" {
+		t.Errorf("addedInstanceIDs = %v, want [i-1]", m.addedInstanceIDs)
+	}
+}
+
+func TestEnsureLoadBalancerNetwork(t *testing.T) {
+	m := &mockClient{}
+	lb := New(m, "infra-1")
+
+	info, err := lb.EnsureLoadBalancer(cloudclient.APIEndpointSpec{
+		Name: "api",
+		Type: cloudclient.LoadBalancerTypeNetwork,
+	})
+	if err != nil {
+		t.Fatalf("EnsureLoadBalancer() returned error: %v", err)
+	}
+	if !m.ensureExternalNLBCalled {
+		t.Error("EnsureExternalNLB was not called for a network-type spec")
+	}
+	if m.createClassicELBCalled {
+		t.Error("CreateClassicELB was called for a network-type spec")
+	}
+	if info.Name != "api" {
+		t.Errorf("Name = %q, want %q", info.Name, "api")
+	}
+}
+
+func TestUpdateLoadBalancerClassicRequiresExisting(t *testing.T) {
+	m := &mockClient{elbExists: false}
+	lb := New(m, "infra-1")
+
+	if err := lb.UpdateLoadBalancer(cloudclient.APIEndpointSpec{Name: "api"}); err == nil {
+		t.Fatal("UpdateLoadBalancer() on a nonexistent classic ELB: got nil error, want one")
+	}
+}
+
+func TestUpdateLoadBalancerNetworkReconciles(t *testing.T) {
+	m := &mockClient{}
+	lb := New(m, "infra-1")
+
+	err := lb.UpdateLoadBalancer(cloudclient.APIEndpointSpec{
+		Name: "api",
+		Type: cloudclient.LoadBalancerTypeNetwork,
+	})
+	if err != nil {
+		t.Fatalf("UpdateLoadBalancer() returned error: %v", err)
+	}
+	if !m.ensureExternalNLBCalled {
+		t.Error("EnsureExternalNLB was not called when updating a network-type spec")
+	}
+}
+
+func TestGetLoadBalancerFallsBackToNLB(t *testing.T) {
+	m := &mockClient{
+		elbExists: false,
+		nlbs: []awsclient.LoadBalancerV2{
+			{LoadBalancerName: "other"},
+			{LoadBalancerName: "api", DNSName: "api.example.com"},
+		},
+	}
+	lb := New(m, "infra-1")
+
+	info, ok, err := lb.GetLoadBalancer("api")
+	if err != nil || !ok {
+		t.Fatalf("GetLoadBalancer() = (_, %v, %v), want (_, true, nil)", ok, err)
+	}
+	if info.DNSName != "api.example.com" {
+		t.Errorf("DNSName = %q, want %q", info.DNSName, "api.example.com")
+	}
+
+	if _, ok, err := lb.GetLoadBalancer("missing"); err != nil || ok {
+		t.Fatalf("GetLoadBalancer(missing) = (_, %v, %v), want (_, false, nil)", ok, err)
+	}
+}
+
+func TestEnsureLoadBalancerDeletedClassic(t *testing.T) {
+	m := &mockClient{elbExists: true}
+	lb := New(m, "infra-1")
+
+	if err := lb.EnsureLoadBalancerDeleted("api"); err != nil {
+		t.Fatalf("EnsureLoadBalancerDeleted() returned error: %v", err)
+	}
+	if m.deletedClassicELBName != "api" {
+		t.Errorf("DeleteClassicELB was called with %q, want %q", m.deletedClassicELBName, "api")
+	}
+}
+
+func TestEnsureLoadBalancerDeletedNetwork(t *testing.T) {
+	m := &mockClient{
+		nlbs: []awsclient.LoadBalancerV2{{LoadBalancerName: "api", LoadBalancerArn: "arn:aws:elasticloadbalancing:1"}},
+	}
+	lb := New(m, "infra-1")
+
+	if err := lb.EnsureLoadBalancerDeleted("api"); err != nil {
+		t.Fatalf("EnsureLoadBalancerDeleted() returned error: %v", err)
+	}
+	if m.deletedExternalLBArn != "arn:aws:elasticloadbalancing:1" {
+		t.Errorf("DeleteExternalLoadBalancer was called with %q, want %q", m.deletedExternalLBArn, "arn:aws:elasticloadbalancing:1")
+	}
+}
+
+func TestEnsureLoadBalancerDeletedNoop(t *testing.T) {
+	m := &mockClient{}
+	lb := New(m, "infra-1")
+
+	if err := lb.EnsureLoadBalancerDeleted("missing"); err != nil {
+		t.Fatalf("EnsureLoadBalancerDeleted() on a nonexistent load balancer returned error: %v", err)
+	}
+}
+
+func TestEnsureLoadBalancerDeletedPropagatesError(t *testing.T) {
+	wantErr := errors.New("delete failed")
+	m := &mockClient{elbExists: true, deleteClassicELBErr: wantErr}
+	lb := New(m, "infra-1")
+
+	if err := lb.EnsureLoadBalancerDeleted("api"); !errors.Is(err, wantErr) {
+		t.Errorf("EnsureLoadBalancerDeleted() = %v, want %v", err, wantErr)
+	}
+}
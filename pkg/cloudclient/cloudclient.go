@@ -0,0 +1,103 @@
+// Package cloudclient defines a provider-neutral interface for managing the
+// load balancer that fronts a cluster's external kube-apiserver endpoint.
+// It is modeled after the Kubernetes cloudprovider.LoadBalancer contract so
+// that the rest of the operator (and its tests) don't need to know whether
+// the underlying cloud is AWS, GCP, or Azure.
+package cloudclient
+
+// EndpointScheme describes whether an API endpoint load balancer is
+// reachable from outside the VPC/project or only from within it.
+type EndpointScheme string
+
+const (
+	// EndpointSchemeInternal is for load balancers only reachable from
+	// within the cluster's VPC/project.
+	EndpointSchemeInternal EndpointScheme = "internal"
+	// EndpointSchemeInternetFacing is for load balancers reachable from the
+	// public internet.
+	EndpointSchemeInternetFacing EndpointScheme = "internet-facing"
+)
+
+// LoadBalancerType selects which kind of load balancer a backend should
+// provision for an APIEndpointSpec.
+type LoadBalancerType string
+
+const (
+	// LoadBalancerTypeClassic is a classic/Layer-4 load balancer addressed
+	// by instance ID (eg an AWS classic ELB). An unset Type (the empty
+	// string) is handled the same way, so existing callers that don't set
+	// Type keep today's behavior.
+	LoadBalancerTypeClassic LoadBalancerType = "classic"
+	// LoadBalancerTypeNetwork is a network load balancer addressed by IP
+	// (eg an AWS NLB).
+	LoadBalancerTypeNetwork LoadBalancerType = "network"
+)
+
+// HealthCheck describes how the load balancer should determine whether a
+// backend target is healthy.
+type HealthCheck struct {
+	Protocol           string
+	Path               string
+	Port               int64
+	HealthyThreshold   int64
+	UnhealthyThreshold int64
+	IntervalSeconds    int64
+	TimeoutSeconds     int64
+}
+
+// APIEndpointSpec is the cloud-agnostic description of the load balancer
+// fronting a cluster's external API endpoint. It intentionally avoids any
+// AWS (or other provider) specific types so it can be shared by every
+// LoadBalancer backend.
+type APIEndpointSpec struct {
+	// Name identifies the load balancer. Backends are expected to derive
+	// any provider-specific naming from this.
+	Name string
+	// Type selects classic vs. network load balancing. Defaults to
+	// LoadBalancerTypeClassic.
+	Type LoadBalancerType
+	// Scheme controls public vs. internal reachability.
+	Scheme EndpointScheme
+	// VPCID is the VPC the load balancer's targets live in. Only consulted
+	// by IP-addressed backends (eg NLB target groups).
+	VPCID string
+	// Subnets are the backend's provider-specific subnet identifiers the
+	// load balancer should attach to.
+	Subnets []string
+	// ListenerPorts are the ports the load balancer should listen on and
+	// forward to the same port on the backend targets.
+	ListenerPorts []int64
+	// HealthCheck configures how backend targets are health checked.
+	HealthCheck HealthCheck
+	// TargetInstanceIDs are backend targets addressed by instance ID (eg
+	// classic ELB targets).
+	TargetInstanceIDs []string
+	// TargetIPs are backend targets addressed by IP (eg NLB IP targets).
+	TargetIPs []string
+}
+
+// LoadBalancerInfo is what a LoadBalancer backend returns about the load
+// balancer it manages, regardless of cloud provider.
+type LoadBalancerInfo struct {
+	Name              string
+	DNSName           string
+	CanonicalHostedID string
+}
+
+// LoadBalancer is the provider-neutral contract a cloud backend implements
+// to manage a cluster's external API endpoint load balancer. It mirrors
+// Kubernetes' cloudprovider.LoadBalancer interface.
+type LoadBalancer interface {
+	// EnsureLoadBalancer creates the load balancer described by spec if it
+	// does not already exist, or returns the existing one.
+	EnsureLoadBalancer(spec APIEndpointSpec) (*LoadBalancerInfo, error)
+	// UpdateLoadBalancer reconciles an existing load balancer's targets and
+	// configuration to match spec.
+	UpdateLoadBalancer(spec APIEndpointSpec) error
+	// GetLoadBalancer returns the current state of the named load balancer,
+	// and whether it exists.
+	GetLoadBalancer(name string) (*LoadBalancerInfo, bool, error)
+	// EnsureLoadBalancerDeleted deletes the named load balancer. It is a
+	// no-op if the load balancer does not exist.
+	EnsureLoadBalancerDeleted(name string) error
+}
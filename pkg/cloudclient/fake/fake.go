@@ -0,0 +1,65 @@
+// Package fake provides an in-memory cloudclient.LoadBalancer used by
+// controller tests that need a load balancer backend without talking to
+// any real cloud API.
+package fake
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+)
+
+// loadBalancer is an in-memory cloudclient.LoadBalancer. It is safe for
+// concurrent use.
+type loadBalancer struct {
+	mu  sync.Mutex
+	lbs map[string]*cloudclient.LoadBalancerInfo
+}
+
+// New returns an empty fake cloudclient.LoadBalancer.
+func New() cloudclient.LoadBalancer {
+	return &loadBalancer{lbs: map[string]*cloudclient.LoadBalancerInfo{}}
+}
+
+func (f *loadBalancer) EnsureLoadBalancer(spec cloudclient.APIEndpointSpec) (*cloudclient.LoadBalancerInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if info, ok := f.lbs[spec.Name]; ok {
+		return info, nil
+	}
+	info := &cloudclient.LoadBalancerInfo{
+		Name:              spec.Name,
+		DNSName:           fmt.Sprintf("%s.fake.example.com", spec.Name),
+		CanonicalHostedID: "FAKEHOSTEDZONE",
+	}
+	f.lbs[spec.Name] = info
+	return info, nil
+}
+
+func (f *loadBalancer) UpdateLoadBalancer(spec cloudclient.APIEndpointSpec) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.lbs[spec.Name]; !ok {
+		return fmt.Errorf("load balancer %s does not exist", spec.Name)
+	}
+	return nil
+}
+
+func (f *loadBalancer) GetLoadBalancer(name string) (*cloudclient.LoadBalancerInfo, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, ok := f.lbs[name]
+	return info, ok, nil
+}
+
+func (f *loadBalancer) EnsureLoadBalancerDeleted(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.lbs, name)
+	return nil
+}
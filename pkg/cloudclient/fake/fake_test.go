@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+)
+
+func TestEnsureLoadBalancerCreatesOnce(t *testing.T) {
+	lb := New()
+	spec := cloudclient.APIEndpointSpec{Name: "test-lb"}
+
+	first, err := lb.EnsureLoadBalancer(spec)
+	if err != nil {
+		t.Fatalf("EnsureLoadBalancer() returned error: %v", err)
+	}
+	if first.Name != spec.Name {
+		t.Errorf("Name = %q, want %q", first.Name, spec.Name)
+	}
+
+	second, err := lb.EnsureLoadBalancer(spec)
+	if err != nil {
+		t.Fatalf("EnsureLoadBalancer() second call returned error: %v", err)
+	}
+	if second != first {
+		t.Errorf("EnsureLoadBalancer() returned a different *LoadBalancerInfo on the second call, want the same one back")
+	}
+}
+
+func TestUpdateLoadBalancerRequiresExisting(t *testing.T) {
+	lb := New()
+	spec := cloudclient.APIEndpointSpec{Name: "test-lb"}
+
+	if err := lb.UpdateLoadBalancer(spec); err == nil {
+		t.Fatal("UpdateLoadBalancer() on a nonexistent load balancer: got nil error, want one")
+	}
+
+	if _, err := lb.EnsureLoadBalancer(spec); err != nil {
+		t.Fatalf("EnsureLoadBalancer() returned error: %v", err)
+	}
+	if err := lb.UpdateLoadBalancer(spec); err != nil {
+		t.Errorf("UpdateLoadBalancer() on an existing load balancer returned error: %v", err)
+	}
+}
+
+func TestGetLoadBalancer(t *testing.T) {
+	lb := New()
+
+	if _, ok, err := lb.GetLoadBalancer("missing"); err != nil || ok {
+		t.Fatalf("GetLoadBalancer(missing) = (_, %v, %v), want (_, false, nil)", ok, err)
+	}
+
+	spec := cloudclient.APIEndpointSpec{Name: "test-lb"}
+	if _, err := lb.EnsureLoadBalancer(spec); err != nil {
+		t.Fatalf("EnsureLoadBalancer() returned error: %v", err)
+	}
+
+	info, ok, err := lb.GetLoadBalancer(spec.Name)
+	if err != nil || !ok {
+		t.Fatalf("GetLoadBalancer(%s) = (_, %v, %v), want (_, true, nil)", spec.Name, ok, err)
+	}
+	if info.Name != spec.Name {
+		t.Errorf("Name = %q, want %q", info.Name, spec.Name)
+	}
+}
+
+func TestEnsureLoadBalancerDeleted(t *testing.T) {
+	lb := New()
+	spec := cloudclient.APIEndpointSpec{Name: "test-lb"}
+	if _, err := lb.EnsureLoadBalancer(spec); err != nil {
+		t.Fatalf("EnsureLoadBalancer() returned error: %v", err)
+	}
+
+	if err := lb.EnsureLoadBalancerDeleted(spec.Name); err != nil {
+		t.Fatalf("EnsureLoadBalancerDeleted() returned error: %v", err)
+	}
+	if _, ok, _ := lb.GetLoadBalancer(spec.Name); ok {
+		t.Errorf("GetLoadBalancer() found %s after EnsureLoadBalancerDeleted(), want not found", spec.Name)
+	}
+
+	// Deleting a load balancer that was never created is a no-op.
+	if err := lb.EnsureLoadBalancerDeleted("never-created"); err != nil {
+		t.Errorf("EnsureLoadBalancerDeleted() on a nonexistent load balancer returned error: %v", err)
+	}
+}